@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -9,8 +10,11 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Create a new spinner with default configuration
-	spinner := multispinner.Create(multispinner.DefaultConfig())
+	spinner := multispinner.Create(ctx, multispinner.DefaultConfig())
+	defer spinner.Close()
 
 	// Register spinners for each task
 	spinner1 := spinner.Register()
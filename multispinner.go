@@ -1,31 +1,153 @@
 package multispinner
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/term"
+)
+
+// Mode controls how a Spinner renders: animated ANSI escapes for an
+// interactive terminal, or plain/JSON log lines suitable for CI logs
+// and other non-TTY writers.
+type Mode int
+
+const (
+	// ModeAuto selects ModeTTY if Config.Writer is a terminal, and
+	// ModePlain otherwise. It is the zero value, so a Config left
+	// unset auto-detects.
+	ModeAuto Mode = iota
+	// ModeTTY animates spinners in place using ANSI cursor escapes.
+	ModeTTY
+	// ModePlain emits one line per state transition (start, update,
+	// stop, error) with a timestamp, and no ANSI escapes.
+	ModePlain
+	// ModeJSON emits one newline-delimited JSON event per state
+	// transition, so build systems can parse spinner activity.
+	ModeJSON
+)
+
+// event is the shape of a single ModeJSON log line.
+type event struct {
+	TS        string `json:"ts"`
+	Index     int    `json:"index"`
+	Action    string `json:"action"`
+	Message   string `json:"message"`
+	ElapsedMS int64  `json:"elapsed_ms,omitempty"`
+}
+
+// Sentinel errors returned by the lifecycle methods below so callers
+// can distinguish "already running"/"already stopped" conditions from
+// real failures.
+var (
+	// ErrAlreadyStarted is returned by Start when the spinner at the
+	// given index is already active.
+	ErrAlreadyStarted = errors.New("multispinner: spinner already started")
+	// ErrAlreadyStopped is returned by Stop, StopWithError, and
+	// Shutdown when the spinner (or Spinner instance) is already
+	// stopped.
+	ErrAlreadyStopped = errors.New("multispinner: spinner already stopped")
+	// ErrUnknownIndex is returned when an index does not refer to a
+	// registered spinner.
+	ErrUnknownIndex = errors.New("multispinner: unknown spinner index")
+	// ErrNotProgress is returned by Progress and ProgressAdd when index
+	// refers to a spinner registered with Register rather than
+	// RegisterProgress.
+	ErrNotProgress = errors.New("multispinner: spinner is not a progress bar")
 )
 
+// defaultTermWidth is used when the terminal width can't be queried,
+// e.g. because Writer isn't backed by a *os.File.
+const defaultTermWidth = 80
+
+// CharSets is a catalog of named spinner character sets that can be
+// assigned to a spinner via Config.CharSet or Spinner.SetCharSet. The
+// names loosely follow the conventions used by similar spinner
+// libraries (e.g. briandowns/spinner).
+var CharSets = map[string][]string{
+	"braille":     {"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	"dots":        {"⠁", "⠂", "⠄", "⡀", "⢀", "⠠", "⠐", "⠈"},
+	"arrow":       {"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"},
+	"bouncingBar": {"[    ]", "[=   ]", "[==  ]", "[=== ]", "[ ===]", "[  ==]", "[   =]", "[    ]", "[   =]", "[  ==]", "[ ===]", "[====]", "[=== ]", "[==  ]", "[=   ]"},
+	"pulse":       {".", "o", "O", "o"},
+	"ascii":       {"|", "/", "-", "\\"},
+	"fish":        {">))'>", " >))'>", "  >))'>", "   >))'>"},
+}
+
+// DefaultCharSetName is the CharSets key used when Config.CharSet is
+// left unset.
+const DefaultCharSetName = "braille"
+
+// defaultMaxDetailLines bounds how many detail lines Detail/AppendDetail
+// keep for a spinner, both on screen while it runs and in the summary
+// printed when it stops.
+const defaultMaxDetailLines = 5
+
 // Spinner represents a multi-spinner instance
 type Spinner struct {
-	mu           sync.Mutex
-	spinners     []*spinnerInfo
-	successColor string
-	failureColor string
-	frequency    time.Duration
-	stopChan     chan struct{}
-	currentLine  int
-	activeCount  int
-	startLine    int
-	updateChan   chan updateMsg
+	mu             sync.Mutex
+	spinners       []*spinnerInfo
+	successColor   string
+	failureColor   string
+	frequency      time.Duration
+	currentLine    int
+	activeCount    int
+	startLine      int
+	updateChan     chan updateMsg
+	writer         io.Writer
+	defaultCharSet []string
+	mode           Mode
+	termWidth      int32 // accessed atomically; refreshed on SIGWINCH where supported
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	closed bool
 }
 
 type spinnerInfo struct {
-	message string
-	index   int
-	active  bool
-	lineNum int
+	message      string
+	index        int
+	active       bool
+	lineNum      int
+	chars        []string
+	charIndex    int
+	prefix       string
+	suffix       string
+	successGlyph string
+	failureGlyph string
+
+	// details holds the bounded set of secondary status lines rendered
+	// beneath this spinner's row, e.g. recent pod events.
+	details         []string
+	maxDetailLines  int
+	detailTruncated bool
+
+	// startTime records when Start was called, used to report elapsed
+	// duration in ModePlain/ModeJSON stop/error events.
+	startTime time.Time
+
+	// isProgress marks a row registered via RegisterProgress, rendered
+	// as a percentage bar instead of an animated glyph. current is
+	// accessed atomically, so Progress/ProgressAdd update it without
+	// holding s.mu; they still take s.mu briefly to look up the index.
+	isProgress bool
+	total      int64
+	current    int64
+}
+
+// height returns the number of terminal rows this spinner currently
+// occupies: its own row plus any detail lines.
+func (sp *spinnerInfo) height() int {
+	return 1 + len(sp.details)
 }
 
 type updateMsg struct {
@@ -39,6 +161,16 @@ type Config struct {
 	SuccessColor string
 	FailureColor string
 	Frequency    time.Duration
+	// CharSet names an entry in CharSets to use as the default frame
+	// sequence for spinners registered on this instance. If empty,
+	// DefaultCharSetName is used.
+	CharSet string
+	// Writer is where the spinner renders its output. If nil,
+	// os.Stdout is used.
+	Writer io.Writer
+	// Mode selects TTY animation, plain log lines, or JSON log lines.
+	// The zero value, ModeAuto, detects whether Writer is a terminal.
+	Mode Mode
 }
 
 // DefaultConfig returns a default configuration
@@ -47,11 +179,24 @@ func DefaultConfig() Config {
 		SuccessColor: "\033[32m", // Green
 		FailureColor: "\033[31m", // Red
 		Frequency:    100 * time.Millisecond,
+		CharSet:      DefaultCharSetName,
+		Writer:       os.Stdout,
 	}
 }
 
-// Create creates a new spinner instance with the given configuration
-func Create(config Config) *Spinner {
+// WithWriter returns a copy of c that renders to w instead of
+// os.Stdout. This is useful for tests and for embedding multispinner's
+// output inside a larger TUI.
+func (c Config) WithWriter(w io.Writer) Config {
+	c.Writer = w
+	return c
+}
+
+// Create creates a new spinner instance with the given configuration.
+// The spinner's run loop exits when ctx is cancelled; callers that
+// don't need external cancellation can pass context.Background() and
+// rely on Close/Shutdown instead.
+func Create(ctx context.Context, config Config) *Spinner {
 	if config.Frequency == 0 {
 		config.Frequency = DefaultConfig().Frequency
 	}
@@ -61,186 +206,756 @@ func Create(config Config) *Spinner {
 	if config.FailureColor == "" {
 		config.FailureColor = DefaultConfig().FailureColor
 	}
+	if config.CharSet == "" {
+		config.CharSet = DefaultCharSetName
+	}
+	if config.Writer == nil {
+		config.Writer = os.Stdout
+	}
+	if config.Mode == ModeAuto {
+		if isTerminalWriter(config.Writer) {
+			config.Mode = ModeTTY
+		} else {
+			config.Mode = ModePlain
+		}
+	}
 
-	// Reset terminal state
-	fmt.Print("\033[?25h") // Show cursor
-	fmt.Print("\033[0m")   // Reset all attributes
-
-	// Save current cursor position
-	fmt.Print("\033[s")
+	runCtx, cancel := context.WithCancel(ctx)
 
 	s := &Spinner{
 		spinners:     make([]*spinnerInfo, 0),
 		successColor: config.SuccessColor,
 		failureColor: config.FailureColor,
 		frequency:    config.Frequency,
-		stopChan:     make(chan struct{}),
 		currentLine:  0,
 		startLine:    0, // We'll use relative positioning from saved position
 		updateChan:   make(chan updateMsg),
+		writer:       config.Writer,
+		mode:         config.Mode,
+		ctx:          runCtx,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+
+	if s.mode == ModeTTY {
+		// Reset terminal state
+		fmt.Fprint(s.writer, "\033[?25h") // Show cursor
+		fmt.Fprint(s.writer, "\033[0m")   // Reset all attributes
+
+		// Save current cursor position
+		fmt.Fprint(s.writer, "\033[s")
 	}
 
+	s.defaultCharSet = charSetOrDefault(config.CharSet)
+
+	watchWinsize(s)
+
 	// Start the spinner goroutine
 	go s.run()
 
 	return s
 }
 
+// updateTermWidth queries the current terminal width from s.writer, or
+// falls back to defaultTermWidth if it can't be determined, and stores
+// it for progress bar rendering.
+func (s *Spinner) updateTermWidth() {
+	width := defaultTermWidth
+	if f, ok := s.writer.(*os.File); ok {
+		if w, _, err := term.GetSize(int(f.Fd())); err == nil && w > 0 {
+			width = w
+		}
+	}
+	atomic.StoreInt32(&s.termWidth, int32(width))
+}
+
+// charSetOrDefault looks up name in CharSets, falling back to the
+// default char set if name is unknown.
+func charSetOrDefault(name string) []string {
+	if chars, ok := CharSets[name]; ok {
+		return chars
+	}
+	return CharSets[DefaultCharSetName]
+}
+
+// isTerminalWriter reports whether w is a terminal, used to resolve
+// ModeAuto.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// emitEvent writes a ModePlain or ModeJSON log line for a state
+// transition. It is a no-op in ModeTTY. Callers must hold s.mu.
+func (s *Spinner) emitEvent(index int, action, message string, elapsed time.Duration) {
+	switch s.mode {
+	case ModePlain:
+		if elapsed > 0 {
+			fmt.Fprintf(s.writer, "%s %s spinner=%d message=%q elapsed=%s\n",
+				time.Now().Format(time.RFC3339), action, index, message, elapsed)
+		} else {
+			fmt.Fprintf(s.writer, "%s %s spinner=%d message=%q\n",
+				time.Now().Format(time.RFC3339), action, index, message)
+		}
+	case ModeJSON:
+		ev := event{
+			TS:        time.Now().Format(time.RFC3339Nano),
+			Index:     index,
+			Action:    action,
+			Message:   message,
+			ElapsedMS: elapsed.Milliseconds(),
+		}
+		b, err := json.Marshal(ev)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(s.writer, string(b))
+	}
+}
+
+// shutdownTerminal restores the cursor and text attributes before the
+// run loop exits. It is a no-op outside ModeTTY.
+func (s *Spinner) shutdownTerminal() {
+	if s.mode == ModeTTY {
+		fmt.Fprint(s.writer, "\033[?25h") // Show cursor
+		fmt.Fprint(s.writer, "\033[0m")   // Reset all attributes
+	}
+}
+
+// handleUpdate applies a single updateMsg to spinner state, e.g.
+// tracking activeCount and emitting ModePlain/ModeJSON log lines.
+func (s *Spinner) handleUpdate(msg updateMsg) {
+	s.mu.Lock()
+	sp, _ := s.spinnerAt(msg.index)
+	switch msg.action {
+	case "start":
+		if s.mode == ModeTTY && s.activeCount == 0 {
+			fmt.Fprint(s.writer, "\033[?25l") // Hide cursor
+		}
+		s.activeCount++
+		if sp != nil {
+			sp.startTime = time.Now()
+		}
+		s.emitEvent(msg.index, msg.action, msg.message, 0)
+	case "stop", "error":
+		s.activeCount--
+		if s.mode == ModeTTY && s.activeCount == 0 {
+			fmt.Fprint(s.writer, "\033[?25h") // Show cursor
+			fmt.Fprint(s.writer, "\033[0m")   // Reset all attributes
+		}
+		var elapsed time.Duration
+		if sp != nil && !sp.startTime.IsZero() {
+			elapsed = time.Since(sp.startTime)
+		}
+		s.emitEvent(msg.index, msg.action, msg.message, elapsed)
+	case "update":
+		s.emitEvent(msg.index, msg.action, msg.message, 0)
+	}
+	s.mu.Unlock()
+}
+
 // run is the main spinner goroutine that handles all updates
 func (s *Spinner) run() {
-	chars := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	i := 0
+	defer close(s.done)
 	firstRun := true
 
 	for {
+		s.mu.Lock()
+		active := s.activeCount > 0
+		s.mu.Unlock()
+
+		if !active {
+			// Nothing to animate: block instead of busy-spinning so an
+			// idle Spinner embedded in a long-running daemon doesn't
+			// burn a core between tasks.
+			select {
+			case <-s.ctx.Done():
+				s.shutdownTerminal()
+				return
+			case msg := <-s.updateChan:
+				s.handleUpdate(msg)
+			}
+			continue
+		}
+
 		select {
-		case <-s.stopChan:
-			// Clean up terminal state before exiting
-			fmt.Print("\033[?25h") // Show cursor
-			fmt.Print("\033[0m")   // Reset all attributes
+		case <-s.ctx.Done():
+			s.shutdownTerminal()
 			return
 		case msg := <-s.updateChan:
-			s.mu.Lock()
-			switch msg.action {
-			case "start":
-				if s.activeCount == 0 {
-					fmt.Print("\033[?25l") // Hide cursor
-				}
-				s.activeCount++
-			case "stop", "error":
-				s.activeCount--
-				if s.activeCount == 0 {
-					fmt.Print("\033[?25h") // Show cursor
-					fmt.Print("\033[0m")   // Reset all attributes
-				}
-			}
-			s.mu.Unlock()
+			s.handleUpdate(msg)
 		default:
+			if firstRun {
+				firstRun = false
+			} else {
+				// Sleep without holding s.mu so that Progress/ProgressAdd's
+				// brief spinnerAt lookup never waits out a full tick behind
+				// this render pass.
+				time.Sleep(s.frequency)
+			}
+
 			s.mu.Lock()
 			if s.activeCount > 0 {
-				if firstRun {
-					firstRun = false
-				} else {
-					time.Sleep(s.frequency)
-				}
-
-				// Update all active spinners
-				for _, spinner := range s.spinners {
-					if spinner.active {
-						// Move to saved position and then down by the spinner's line number
-						fmt.Print("\033[u")
-						if spinner.lineNum > 0 {
-							fmt.Printf("\033[%dB", spinner.lineNum)
+				if s.mode == ModeTTY {
+					// Update all active spinners, each advancing its own
+					// char set independently.
+					for _, spinner := range s.spinners {
+						if spinner != nil && spinner.active {
+							s.renderActiveRow(spinner)
 						}
-						fmt.Printf("\033[K\033[0m%s %s", chars[i], spinner.message)
 					}
 				}
-				i = (i + 1) % len(chars)
 			}
 			s.mu.Unlock()
 		}
 	}
 }
 
-// Register registers a new spinner and returns its index
+// Register registers a new spinner and returns its index. If an
+// earlier spinner at a lower index has been Unregistered, its slot is
+// reused instead of growing the spinner list, so a long-running
+// program can reuse one Spinner instance across many tasks without
+// accumulating stale rows.
 func (s *Spinner) Register() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	index := len(s.spinners)
-	s.spinners = append(s.spinners, &spinnerInfo{
-		index:   index,
-		lineNum: s.currentLine,
+	return s.register(&spinnerInfo{
+		chars:          s.defaultCharSet,
+		successGlyph:   "✓",
+		failureGlyph:   "✗",
+		maxDetailLines: defaultMaxDetailLines,
 	})
-	s.currentLine++
+}
+
+// register assigns info a slot, reusing the lowest-index Unregistered
+// slot if one is available, and returns its index. Callers must hold
+// s.mu.
+func (s *Spinner) register(info *spinnerInfo) int {
+	index := -1
+	for i, sp := range s.spinners {
+		if sp == nil {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		index = len(s.spinners)
+		s.spinners = append(s.spinners, nil)
+	}
+	info.index = index
+	s.spinners[index] = info
+
+	s.recomputeLines()
 	return index
 }
 
-// Message updates the message for a specific spinner
-func (s *Spinner) Message(index int, message string) {
-	s.updateChan <- updateMsg{
+// RegisterProgress registers a row that renders as a percentage bar,
+// e.g. "[#######-----] 42%  message", instead of an animated spinner.
+// It returns an index usable with Progress, ProgressAdd, and the rest
+// of the per-row API (Start, Stop, Detail, etc). total is the value
+// current reaches at 100%.
+func (s *Spinner) RegisterProgress(total int64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.register(&spinnerInfo{
+		chars:          s.defaultCharSet,
+		successGlyph:   "✓",
+		failureGlyph:   "✗",
+		maxDetailLines: defaultMaxDetailLines,
+		isProgress:     true,
+		total:          total,
+	})
+}
+
+// Progress sets the current value of the progress bar at index. It
+// returns ErrUnknownIndex if index does not refer to a registered
+// spinner, or ErrNotProgress if it was not registered via
+// RegisterProgress.
+func (s *Spinner) Progress(index int, current int64) error {
+	s.mu.Lock()
+	sp, err := s.spinnerAt(index)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if !sp.isProgress {
+		return ErrNotProgress
+	}
+	atomic.StoreInt64(&sp.current, current)
+	return nil
+}
+
+// ProgressAdd adds delta to the current value of the progress bar at
+// index using an atomic counter. The counter update does not hold the
+// main mutex, but the index lookup takes it briefly, so a call can
+// wait behind a render pass or another locked operation. It returns
+// ErrUnknownIndex if index does not refer to a registered spinner, or
+// ErrNotProgress if it was not registered via RegisterProgress.
+func (s *Spinner) ProgressAdd(index int, delta int64) error {
+	s.mu.Lock()
+	sp, err := s.spinnerAt(index)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if !sp.isProgress {
+		return ErrNotProgress
+	}
+	atomic.AddInt64(&sp.current, delta)
+	return nil
+}
+
+// progressBarWidth derives a bar width from the terminal width,
+// leaving room for the brackets, percentage, and message.
+func progressBarWidth(termWidth int) int {
+	width := termWidth - 30
+	if width > 40 {
+		width = 40
+	}
+	if width < 10 {
+		width = 10
+	}
+	return width
+}
+
+// renderProgressLine renders a progress row's bar, percentage, and
+// message. glyph, when non-empty, is shown after the percentage (used
+// to mark a row finalized by StopWithError).
+func (s *Spinner) renderProgressLine(sp *spinnerInfo, glyph string) string {
+	width := progressBarWidth(int(atomic.LoadInt32(&s.termWidth)))
+	total := sp.total
+	if total <= 0 {
+		total = 1
+	}
+	pct := float64(atomic.LoadInt64(&sp.current)) / float64(total)
+	if pct > 1 {
+		pct = 1
+	}
+	if pct < 0 {
+		pct = 0
+	}
+	filled := int(pct * float64(width))
+	bar := "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+	if glyph != "" {
+		return fmt.Sprintf("%s %3d%% %s %s", bar, int(pct*100), glyph, sp.message)
+	}
+	return fmt.Sprintf("%s %3d%%  %s", bar, int(pct*100), sp.message)
+}
+
+// renderActiveRow draws one tick's frame for an active spinner: its
+// own row (a progress bar or the next char-set frame, advancing
+// charIndex), followed by its detail lines, if any. This always runs
+// regardless of isProgress, so a progress row's Detail lines animate
+// in place just like a char-spinner row's. Callers must hold s.mu.
+func (s *Spinner) renderActiveRow(spinner *spinnerInfo) {
+	// Move to saved position and then down by the spinner's line number
+	fmt.Fprint(s.writer, "\033[u")
+	if spinner.lineNum > 0 {
+		fmt.Fprintf(s.writer, "\033[%dB", spinner.lineNum)
+	}
+	if spinner.isProgress {
+		fmt.Fprintf(s.writer, "\033[K\033[0m%s", s.renderProgressLine(spinner, ""))
+	} else {
+		fmt.Fprintf(s.writer, "\033[K\033[0m%s%s%s %s", spinner.prefix, spinner.chars[spinner.charIndex], spinner.suffix, spinner.message)
+		spinner.charIndex = (spinner.charIndex + 1) % len(spinner.chars)
+	}
+
+	for i, line := range spinner.details {
+		fmt.Fprint(s.writer, "\033[u")
+		row := spinner.lineNum + 1 + i
+		if row > 0 {
+			fmt.Fprintf(s.writer, "\033[%dB", row)
+		}
+		if i == 0 && spinner.detailTruncated {
+			fmt.Fprintf(s.writer, "\033[K  … %s", line)
+		} else {
+			fmt.Fprintf(s.writer, "\033[K  %s", line)
+		}
+	}
+}
+
+// Unregister frees the slot occupied by the spinner at index so a
+// later Register call can reuse it, and compacts the layout so
+// spinners below it move up to fill the vacated rows. It returns
+// ErrUnknownIndex if index does not refer to a registered spinner.
+func (s *Spinner) Unregister(index int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		return err
+	}
+	if sp.active {
+		s.activeCount--
+		if s.mode == ModeTTY && s.activeCount == 0 {
+			fmt.Fprint(s.writer, "\033[?25h") // Show cursor
+			fmt.Fprint(s.writer, "\033[0m")   // Reset all attributes
+		}
+	}
+
+	prevTotal := s.currentLine
+	s.spinners[index] = nil
+	s.recomputeLines()
+	s.clearVacatedRows(prevTotal)
+	return nil
+}
+
+// spinnerAt returns the spinner registered at index, or ErrUnknownIndex
+// if index is out of range or has been Unregistered. Callers must hold
+// s.mu.
+func (s *Spinner) spinnerAt(index int) (*spinnerInfo, error) {
+	if index < 0 || index >= len(s.spinners) || s.spinners[index] == nil {
+		return nil, ErrUnknownIndex
+	}
+	return s.spinners[index], nil
+}
+
+// recomputeLines reassigns lineNum for every still-registered spinner
+// based on its position in s.spinners and the height of the spinners
+// above it, then updates currentLine to the total number of rows in
+// use. Unregistered (nil) slots contribute no rows. Callers must hold
+// s.mu.
+func (s *Spinner) recomputeLines() {
+	line := 0
+	for _, sp := range s.spinners {
+		if sp == nil {
+			continue
+		}
+		sp.lineNum = line
+		line += sp.height()
+	}
+	s.currentLine = line
+}
+
+// clearVacatedRows erases the terminal rows between the current total
+// height and prevTotal, used after a change shrinks the layout. It is a
+// no-op outside ModeTTY. Callers must hold s.mu.
+func (s *Spinner) clearVacatedRows(prevTotal int) {
+	if s.mode != ModeTTY {
+		return
+	}
+	for row := s.currentLine; row < prevTotal; row++ {
+		fmt.Fprint(s.writer, "\033[u")
+		if row > 0 {
+			fmt.Fprintf(s.writer, "\033[%dB", row)
+		}
+		fmt.Fprint(s.writer, "\033[K")
+	}
+}
+
+// setDetail replaces the detail lines for the spinner at index,
+// capping them to its maxDetailLines and clearing any terminal rows
+// vacated by a shrinking detail block. Callers must hold s.mu.
+func (s *Spinner) setDetail(index int, lines []string) error {
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		return err
+	}
+
+	sp.detailTruncated = false
+	if len(lines) > sp.maxDetailLines {
+		lines = lines[len(lines)-sp.maxDetailLines:]
+		sp.detailTruncated = true
+	}
+	sp.details = lines
+
+	prevTotal := s.currentLine
+	s.recomputeLines()
+	s.clearVacatedRows(prevTotal)
+	return nil
+}
+
+// Detail replaces the secondary status lines rendered beneath the
+// spinner at index, e.g. "waiting for pod X, event: ImagePullBackOff
+// (seen 6x)". Lines beyond the spinner's cap are dropped, oldest
+// first, and the oldest surviving line is marked with an ellipsis.
+func (s *Spinner) Detail(index int, lines []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setDetail(index, lines)
+}
+
+// AppendDetail appends a single detail line to the spinner at index.
+func (s *Spinner) AppendDetail(index int, line string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		return err
+	}
+	return s.setDetail(index, append(sp.details, line))
+}
+
+// ClearDetail removes all detail lines from the spinner at index.
+func (s *Spinner) ClearDetail(index int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setDetail(index, nil)
+}
+
+// SetCharSet overrides the frame sequence used by the spinner at
+// index. It can be called at any time, including while the spinner is
+// running.
+func (s *Spinner) SetCharSet(index int, chars []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, err := s.spinnerAt(index)
+	if err != nil || len(chars) == 0 {
+		return
+	}
+	sp.chars = chars
+	sp.charIndex = 0
+}
+
+// SetPrefix sets a string rendered immediately before the spinner
+// glyph for the spinner at index.
+func (s *Spinner) SetPrefix(index int, prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		return
+	}
+	sp.prefix = prefix
+}
+
+// SetSuffix sets a string rendered immediately after the spinner
+// glyph for the spinner at index.
+func (s *Spinner) SetSuffix(index int, suffix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		return
+	}
+	sp.suffix = suffix
+}
+
+// SetGlyphs overrides the glyphs printed by Stop and StopWithError for
+// the spinner at index. Empty strings leave the existing glyph
+// unchanged.
+func (s *Spinner) SetGlyphs(index int, success, failure string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		return
+	}
+	if success != "" {
+		sp.successGlyph = success
+	}
+	if failure != "" {
+		sp.failureGlyph = failure
+	}
+}
+
+// sendUpdate delivers msg to the run loop, or returns ErrAlreadyStopped
+// if the run loop has exited (or exits while the send is pending)
+// instead of blocking forever on the unbuffered updateChan.
+func (s *Spinner) sendUpdate(msg updateMsg) error {
+	select {
+	case s.updateChan <- msg:
+		return nil
+	case <-s.ctx.Done():
+		return ErrAlreadyStopped
+	}
+}
+
+// Message updates the message for a specific spinner. It returns
+// ErrUnknownIndex if index does not refer to a registered spinner, or
+// ErrAlreadyStopped if the Spinner has been shut down.
+func (s *Spinner) Message(index int, message string) error {
+	s.mu.Lock()
+	sp, err := s.spinnerAt(index)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	sp.message = message
+	s.mu.Unlock()
+
+	return s.sendUpdate(updateMsg{
 		index:   index,
 		message: message,
 		action:  "update",
-	}
+	})
 }
 
-// Start starts a spinner with the given message
-func (s *Spinner) Start(index int, message string) {
+// Start starts a spinner with the given message. It returns
+// ErrUnknownIndex if index does not refer to a registered spinner,
+// ErrAlreadyStarted if the spinner is already active, or
+// ErrAlreadyStopped if the Spinner has been shut down.
+func (s *Spinner) Start(index int, message string) error {
 	s.mu.Lock()
-	if index >= len(s.spinners) {
+	sp, err := s.spinnerAt(index)
+	if err != nil {
 		s.mu.Unlock()
-		return
+		return err
 	}
-	s.spinners[index].message = message
-	s.spinners[index].active = true
+	if sp.active {
+		s.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	sp.message = message
+	sp.active = true
 	s.mu.Unlock()
 
-	s.updateChan <- updateMsg{
+	if err := s.sendUpdate(updateMsg{
 		index:   index,
 		message: message,
 		action:  "start",
+	}); err != nil {
+		return err
 	}
+	return nil
 }
 
-// Stop stops a spinner with a success message
-func (s *Spinner) Stop(index int, message string) {
+// Stop stops a spinner with a success message. It returns
+// ErrUnknownIndex if index does not refer to a registered spinner, or
+// ErrAlreadyStopped if the spinner is not currently active or the
+// Spinner has been shut down.
+func (s *Spinner) Stop(index int, message string) error {
 	s.mu.Lock()
-	if index >= len(s.spinners) {
+	spinner, err := s.spinnerAt(index)
+	if err != nil {
 		s.mu.Unlock()
-		return
+		return err
 	}
-
-	spinner := s.spinners[index]
 	if !spinner.active {
 		s.mu.Unlock()
-		return
+		return ErrAlreadyStopped
 	}
 	spinner.active = false
 	s.mu.Unlock()
 
-	s.updateChan <- updateMsg{
+	if err := s.sendUpdate(updateMsg{
 		index:   index,
 		message: message,
 		action:  "stop",
+	}); err != nil {
+		return err
 	}
 
-	// Print the final message
-	fmt.Print("\033[u")
-	if spinner.lineNum > 0 {
-		fmt.Printf("\033[%dB", spinner.lineNum)
+	if s.mode == ModeTTY {
+		// Print the final message. Hold s.mu so this can't interleave
+		// with a concurrent recomputeLines (Detail/Register/Unregister)
+		// rewriting spinner.lineNum/details, or with the run loop's own
+		// locked render of other rows.
+		s.mu.Lock()
+		fmt.Fprint(s.writer, "\033[u")
+		if spinner.lineNum > 0 {
+			fmt.Fprintf(s.writer, "\033[%dB", spinner.lineNum)
+		}
+		if spinner.isProgress {
+			atomic.StoreInt64(&spinner.current, spinner.total)
+			spinner.message = message
+			fmt.Fprintf(s.writer, "\033[K%s\033[0m\n", s.renderProgressLine(spinner, spinner.successGlyph))
+		} else {
+			fmt.Fprintf(s.writer, "\033[K%s%s %s\033[0m\n", s.successColor, spinner.successGlyph, message)
+		}
+		s.printDetailSummary(spinner)
+		s.mu.Unlock()
+	}
+	if f, ok := s.writer.(*os.File); ok {
+		f.Sync()
 	}
-	fmt.Printf("\033[K%s %s\033[0m\n", s.successColor+"✓"+s.successColor, message)
-	os.Stdout.Sync()
+	return nil
 }
 
-// StopWithError stops a spinner with an error message
-func (s *Spinner) StopWithError(index int, error string) {
+// StopWithError stops a spinner with an error message. It returns
+// ErrUnknownIndex if index does not refer to a registered spinner, or
+// ErrAlreadyStopped if the spinner is not currently active or the
+// Spinner has been shut down.
+func (s *Spinner) StopWithError(index int, error string) error {
 	s.mu.Lock()
-	if index >= len(s.spinners) {
+	spinner, err := s.spinnerAt(index)
+	if err != nil {
 		s.mu.Unlock()
-		return
+		return err
 	}
-
-	spinner := s.spinners[index]
 	if !spinner.active {
 		s.mu.Unlock()
-		return
+		return ErrAlreadyStopped
 	}
 	spinner.active = false
 	s.mu.Unlock()
 
-	s.updateChan <- updateMsg{
+	if err := s.sendUpdate(updateMsg{
 		index:   index,
 		message: error,
 		action:  "error",
+	}); err != nil {
+		return err
 	}
 
-	// Print the final message
-	fmt.Print("\033[u")
-	if spinner.lineNum > 0 {
-		fmt.Printf("\033[%dB", spinner.lineNum)
+	if s.mode == ModeTTY {
+		// Print the final message. Hold s.mu so this can't interleave
+		// with a concurrent recomputeLines (Detail/Register/Unregister)
+		// rewriting spinner.lineNum/details, or with the run loop's own
+		// locked render of other rows.
+		s.mu.Lock()
+		fmt.Fprint(s.writer, "\033[u")
+		if spinner.lineNum > 0 {
+			fmt.Fprintf(s.writer, "\033[%dB", spinner.lineNum)
+		}
+		if spinner.isProgress {
+			// Leave current at its last value; only the glyph marks failure.
+			spinner.message = error
+			fmt.Fprintf(s.writer, "\033[K%s\033[0m\n", s.renderProgressLine(spinner, spinner.failureGlyph))
+		} else {
+			fmt.Fprintf(s.writer, "\033[K%s%s %s\033[0m\n", s.failureColor, spinner.failureGlyph, error)
+		}
+		s.printDetailSummary(spinner)
+		s.mu.Unlock()
+	}
+	if f, ok := s.writer.(*os.File); ok {
+		f.Sync()
+	}
+	return nil
+}
+
+// printDetailSummary collapses a stopped spinner's detail block into a
+// static, table-like summary of its last maxDetailLines entries, so
+// accumulated warnings/events remain visible after the task finishes.
+// Callers must hold s.mu.
+func (s *Spinner) printDetailSummary(spinner *spinnerInfo) {
+	for _, line := range spinner.details {
+		fmt.Fprintf(s.writer, "\033[K    %s\n", line)
+	}
+}
+
+// Close stops the spinner's run loop, restores the cursor, and
+// returns. It is equivalent to Shutdown(context.Background()).
+func (s *Spinner) Close() error {
+	return s.Shutdown(context.Background())
+}
+
+// Shutdown cancels the spinner's run loop and waits for it to restore
+// the cursor and exit, or for ctx to be done, whichever happens first.
+// It returns ErrAlreadyStopped if the spinner has already been shut
+// down. Start/Stop/StopWithError/Message calls racing a concurrent
+// Shutdown fail with ErrAlreadyStopped rather than being flushed.
+func (s *Spinner) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrAlreadyStopped
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	fmt.Printf("\033[K%s %s\033[0m\n", s.failureColor+"✗"+s.failureColor, error)
-	os.Stdout.Sync()
 }
@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package multispinner
+
+// watchWinsize records the initial terminal width. Windows has no
+// SIGWINCH equivalent wired up here, so the width is fixed for the
+// life of the Spinner.
+func watchWinsize(s *Spinner) {
+	s.updateTermWidth()
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+// +build !windows
+
+package multispinner
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchWinsize records the initial terminal width and, on platforms
+// that deliver it, keeps it current by listening for SIGWINCH until
+// the spinner is shut down.
+func watchWinsize(s *Spinner) {
+	s.updateTermWidth()
+
+	if _, ok := s.writer.(*os.File); !ok {
+		return
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGWINCH)
+
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ch:
+				s.updateTermWidth()
+			}
+		}
+	}()
+}